@@ -0,0 +1,56 @@
+// Command fyne-i18n-extract scans a Go module for strings passed to the lang package's
+// localization functions and writes them out as a go-i18n JSON template or a gettext .pot file,
+// ready to hand to a translator.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "directory to scan for Go source")
+	out := flag.String("out", "translate.en.json", "output file, .json for a go-i18n template or .pot for gettext")
+	merge := flag.String("merge", "", "existing go-i18n JSON template to merge Other/One values from")
+	flag.Parse()
+
+	messages, err := lang.ExtractMessages(*dir)
+	if err != nil {
+		log.Fatalf("fyne-i18n-extract: %s", err)
+	}
+
+	data, err := render(messages, *out, *merge)
+	if err != nil {
+		log.Fatalf("fyne-i18n-extract: %s", err)
+	}
+
+	if err := os.WriteFile(*out, data, 0644); err != nil {
+		log.Fatalf("fyne-i18n-extract: %s", err)
+	}
+	fmt.Printf("wrote %d messages to %s\n", len(messages), *out)
+}
+
+func render(messages []lang.ExtractedMessage, out, merge string) ([]byte, error) {
+	if strings.EqualFold(filepath.Ext(out), ".pot") {
+		return lang.WritePOT(messages), nil
+	}
+
+	prior := map[string]any{}
+	if merge != "" {
+		raw, err := os.ReadFile(merge)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &prior); err != nil {
+			return nil, err
+		}
+	}
+	return lang.WriteGoI18nTemplate(messages, prior)
+}
@@ -0,0 +1,174 @@
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ExtractedMessage is a single translatable string found by ExtractMessages, together with
+// every source location it was called from.
+//
+// Since 2.6
+type ExtractedMessage struct {
+	ID      string
+	Other   string
+	Plural  bool
+	Sources []string // "file.go:line"
+}
+
+// ExtractMessages scans every .go file under dir for calls to lang.L, lang.X, lang.N, Localize,
+// LocalizeKey and LocalizePlural, returning one ExtractedMessage per distinct ID in a stable,
+// ID-sorted order. It is the library equivalent of the fyne-i18n-extract command.
+//
+// Since 2.6
+func ExtractMessages(dir string) ([]ExtractedMessage, error) {
+	found := map[string]*ExtractedMessage{}
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			name, plural, fallbackArg := extractCallKind(call)
+			if name == "" || len(call.Args) == 0 {
+				return true
+			}
+			id, ok := stringLit(call.Args[0])
+			if !ok {
+				return true
+			}
+
+			msg, exists := found[id]
+			if !exists {
+				msg = &ExtractedMessage{ID: id, Other: id}
+				found[id] = msg
+			}
+			msg.Plural = msg.Plural || plural
+			if fallbackArg >= 0 && fallbackArg < len(call.Args) {
+				if fallback, ok := stringLit(call.Args[fallbackArg]); ok {
+					msg.Other = fallback
+				}
+			}
+
+			pos := fset.Position(call.Pos())
+			msg.Sources = append(msg.Sources, fmt.Sprintf("%s:%d", pos.Filename, pos.Line))
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]ExtractedMessage, 0, len(found))
+	for _, msg := range found {
+		messages = append(messages, *msg)
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, nil
+}
+
+// extractCallKind identifies whether call is a localization call we extract strings from,
+// returning its name, whether it carries a plural count, and the index of an explicit
+// fallback argument (or -1 if there is none).
+func extractCallKind(call *ast.CallExpr) (name string, plural bool, fallbackArg int) {
+	var ident string
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		ident = fn.Name
+	case *ast.SelectorExpr:
+		ident = fn.Sel.Name
+	default:
+		return "", false, -1
+	}
+
+	switch ident {
+	case "L", "Localize":
+		return ident, false, -1
+	case "X", "LocalizeKey":
+		return ident, false, 1
+	case "N", "LocalizePlural":
+		return ident, true, -1
+	}
+	return "", false, -1
+}
+
+func stringLit(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+// WriteGoI18nTemplate renders messages as a go-i18n JSON translation template. Values found
+// under the same ID in prior, as produced by unmarshalling an earlier template, are carried
+// over so a message that has already been translated is not reset back to its source text.
+//
+// Since 2.6
+func WriteGoI18nTemplate(messages []ExtractedMessage, prior map[string]any) ([]byte, error) {
+	out := make(map[string]any, len(messages))
+	for _, msg := range messages {
+		entry := map[string]any{"other": msg.Other}
+		if msg.Plural {
+			entry["one"] = msg.Other
+		}
+		if existing, ok := prior[msg.ID].(map[string]any); ok {
+			if other, ok := existing["other"].(string); ok {
+				entry["other"] = other
+			}
+			if one, ok := existing["one"].(string); ok {
+				entry["one"] = one
+			}
+		}
+		out[msg.ID] = entry
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// WritePOT renders messages as a gettext .pot template, with a "#:" source reference comment
+// for every call site so translators can find the surrounding context.
+//
+// Since 2.6
+func WritePOT(messages []ExtractedMessage) []byte {
+	out := &strings.Builder{}
+	out.WriteString("msgid \"\"\nmsgstr \"\"\n\n")
+
+	for _, msg := range messages {
+		for _, src := range msg.Sources {
+			fmt.Fprintf(out, "#: %s\n", src)
+		}
+		fmt.Fprintf(out, "msgid %q\n", msg.ID)
+		if msg.Plural {
+			fmt.Fprintf(out, "msgid_plural %q\n", msg.Other)
+			out.WriteString("msgstr[0] \"\"\nmsgstr[1] \"\"\n\n")
+			continue
+		}
+		out.WriteString("msgstr \"\"\n\n")
+	}
+	return []byte(out.String())
+}
@@ -0,0 +1,94 @@
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestExtractMessagesFindsAllCallKinds confirms ExtractMessages finds L, X and N calls, merges
+// duplicate IDs into one message with every call site recorded, and captures an explicit
+// fallback argument passed to X.
+func TestExtractMessagesFindsAllCallKinds(t *testing.T) {
+	dir := t.TempDir()
+	src := `package sample
+
+import "fyne.io/fyne/v2/lang"
+
+func run() {
+	lang.L("hello")
+	lang.X("greeting", "hi there")
+	lang.N("item", 3)
+	lang.L("hello")
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "sample.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	messages, err := ExtractMessages(dir)
+	if err != nil {
+		t.Fatalf("ExtractMessages returned error: %v", err)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("got %d messages, want 3: %+v", len(messages), messages)
+	}
+
+	byID := make(map[string]ExtractedMessage, len(messages))
+	for _, m := range messages {
+		byID[m.ID] = m
+	}
+
+	hello, ok := byID["hello"]
+	if !ok {
+		t.Fatalf("missing %q in %+v", "hello", messages)
+	}
+	if len(hello.Sources) != 2 {
+		t.Errorf("got %d call sites for %q, want 2: %v", len(hello.Sources), "hello", hello.Sources)
+	}
+
+	greeting, ok := byID["greeting"]
+	if !ok || greeting.Other != "hi there" {
+		t.Errorf("got %+v, want Other=%q", greeting, "hi there")
+	}
+
+	item, ok := byID["item"]
+	if !ok || !item.Plural {
+		t.Errorf("got %+v, want Plural=true", item)
+	}
+}
+
+// TestWritePOTIncludesSourceComments confirms WritePOT renders a "#:" comment per call site and
+// a msgid_plural block for plural messages.
+func TestWritePOTIncludesSourceComments(t *testing.T) {
+	messages := []ExtractedMessage{
+		{ID: "hello", Other: "hello", Sources: []string{"main.go:5"}},
+		{ID: "item", Other: "items", Plural: true, Sources: []string{"main.go:6"}},
+	}
+
+	pot := string(WritePOT(messages))
+	if !strings.Contains(pot, "#: main.go:5") {
+		t.Errorf("missing source comment for %q in:\n%s", "hello", pot)
+	}
+	if !strings.Contains(pot, `msgid_plural "items"`) {
+		t.Errorf("missing msgid_plural for %q in:\n%s", "item", pot)
+	}
+}
+
+// TestWriteGoI18nTemplateCarriesOverPriorTranslations confirms an existing "other"/"one"
+// translation found in prior is kept rather than being reset back to the extracted source text.
+func TestWriteGoI18nTemplateCarriesOverPriorTranslations(t *testing.T) {
+	messages := []ExtractedMessage{{ID: "hello", Other: "hello"}}
+	prior := map[string]any{
+		"hello": map[string]any{"other": "bonjour"},
+	}
+
+	data, err := WriteGoI18nTemplate(messages, prior)
+	if err != nil {
+		t.Fatalf("WriteGoI18nTemplate returned error: %v", err)
+	}
+	if !strings.Contains(string(data), "bonjour") {
+		t.Errorf("got %s, want it to contain the carried-over translation %q", data, "bonjour")
+	}
+}
@@ -0,0 +1,276 @@
+package lang
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// AddTranslationsPO loads a gettext ".po" or compiled ".mo" translation file for locale l, so
+// teams already using Poedit, Weblate or Crowdin can populate the bundle without converting
+// their catalog to JSON.
+//
+// Since 2.6
+func AddTranslationsPO(data []byte, l fyne.Locale) error {
+	header, entries, err := parseGettext(data)
+	if err != nil {
+		return err
+	}
+
+	tag := language.Make(l.String())
+	messages := buildMessages(tag, header, entries)
+	if err := bundle.AddMessages(tag, messages...); err != nil {
+		return err
+	}
+	rememberTranslated(tag)
+	return nil
+}
+
+// gettextEntry holds one msgid's raw, un-collapsed plural forms, keyed by the msgstr[N] index
+// gettext assigned them - which CLDR category each index represents depends on the catalog's
+// Plural-Forms header, so that mapping is deferred to buildMessages.
+type gettextEntry struct {
+	id, idPlural string
+	forms        map[int]string
+}
+
+func parseGettext(data []byte) (header string, entries []gettextEntry, err error) {
+	if isMOFile(data) {
+		return parseMO(data)
+	}
+	return parsePO(data)
+}
+
+func isMOFile(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+	switch binary.LittleEndian.Uint32(data[0:4]) {
+	case moMagicLE, moMagicBE:
+		return true
+	default:
+		return false
+	}
+}
+
+// buildMessages assigns each entry's raw msgstr[] forms to CLDR plural categories (One, Two,
+// Few, Many, Other) using the catalog's Plural-Forms header, falling back to the common
+// English-like one/other split if the header is absent or cannot be parsed.
+func buildMessages(tag language.Tag, header string, entries []gettextEntry) []*i18n.Message {
+	expr, ok := parsePluralForms(header)
+
+	messages := make([]*i18n.Message, 0, len(entries))
+	for _, entry := range entries {
+		if entry.idPlural == "" {
+			messages = append(messages, &i18n.Message{ID: entry.id, Other: entry.forms[0]})
+			continue
+		}
+
+		msg := &i18n.Message{ID: entry.id}
+		if ok {
+			assignPluralForms(tag, msg, entry.forms, expr)
+		} else {
+			msg.One = entry.forms[0]
+		}
+		if msg.Other == "" {
+			msg.Other = entry.forms[len(entry.forms)-1]
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+// assignPluralForms maps each CLDR plural category, as tag's own cardinal plural rules define it,
+// to the msgstr[] index that expr selects for a representative sample count of that category.
+func assignPluralForms(tag language.Tag, msg *i18n.Message, forms map[int]string, expr pluralExpr) {
+	for _, cat := range []plural.Form{plural.Zero, plural.One, plural.Two, plural.Few, plural.Many, plural.Other} {
+		n, found := sampleFor(tag, cat)
+		if !found {
+			continue
+		}
+		str, ok := forms[expr(n)]
+		if !ok || str == "" {
+			continue
+		}
+		setPluralField(msg, cat, str)
+	}
+}
+
+// sampleFor returns a small representative count n for cat under tag's CLDR cardinal plural
+// rules (e.g. Polish distinguishes "few" and "many" where English only has "one"/"other"), so the
+// gettext Plural-Forms expression can be evaluated at an n that is actually in that category.
+func sampleFor(tag language.Tag, cat plural.Form) (n int, found bool) {
+	for _, candidate := range []int{0, 1, 2, 3, 4, 5, 10, 11, 12, 20, 21, 100, 101} {
+		if plural.Cardinal.MatchPlural(tag, candidate, 0, 0, 0, 0) == cat {
+			return candidate, true
+		}
+	}
+	return 0, false
+}
+
+func setPluralField(msg *i18n.Message, cat plural.Form, str string) {
+	switch cat {
+	case plural.Zero:
+		msg.Zero = str
+	case plural.One:
+		msg.One = str
+	case plural.Two:
+		msg.Two = str
+	case plural.Few:
+		msg.Few = str
+	case plural.Many:
+		msg.Many = str
+	case plural.Other:
+		msg.Other = str
+	}
+}
+
+// parsePO parses the textual gettext format: msgid/msgstr pairs, msgid_plural and indexed
+// msgstr[N] plural forms, "#"-prefixed comments and quoted-string line continuations. The
+// returned header is the msgstr of the empty-msgid entry, which carries metadata such as
+// Plural-Forms rather than a translation.
+func parsePO(data []byte) (header string, entries []gettextEntry, err error) {
+	entry := gettextEntry{forms: map[int]string{}}
+	lastKey := ""
+
+	flush := func() {
+		defer func() { entry = gettextEntry{forms: map[int]string{}} }()
+		if entry.id == "" && entry.idPlural == "" {
+			if header == "" {
+				header = entry.forms[0]
+			}
+			return
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "msgid_plural "):
+			entry.idPlural = unquotePO(line[len("msgid_plural "):])
+			lastKey = "idPlural"
+		case strings.HasPrefix(line, "msgid "):
+			entry.id = unquotePO(line[len("msgid "):])
+			lastKey = "id"
+		case strings.HasPrefix(line, "msgstr["):
+			end := strings.Index(line, "]")
+			idx, convErr := strconv.Atoi(line[len("msgstr["):end])
+			if convErr != nil {
+				return "", nil, convErr
+			}
+			entry.forms[idx] = unquotePO(line[end+1:])
+			lastKey = fmt.Sprintf("str%d", idx)
+		case strings.HasPrefix(line, "msgstr "):
+			entry.forms[0] = unquotePO(line[len("msgstr "):])
+			lastKey = "str0"
+		case strings.HasPrefix(line, "\""):
+			appendPOContinuation(&entry, lastKey, unquotePO(line))
+		}
+	}
+	flush()
+
+	return header, entries, nil
+}
+
+func appendPOContinuation(entry *gettextEntry, key, value string) {
+	switch {
+	case key == "id":
+		entry.id += value
+	case key == "idPlural":
+		entry.idPlural += value
+	case strings.HasPrefix(key, "str"):
+		idx, _ := strconv.Atoi(strings.TrimPrefix(key, "str"))
+		entry.forms[idx] += value
+	}
+}
+
+func unquotePO(s string) string {
+	s = strings.TrimSpace(s)
+	if unq, err := strconv.Unquote(s); err == nil {
+		return unq
+	}
+	return strings.Trim(s, "\"")
+}
+
+const (
+	moMagicLE = 0x950412de
+	moMagicBE = 0xde120495
+)
+
+// parseMO parses a compiled gettext ".mo" file, reading its original/translation string tables.
+// Every offset and length used to index data comes from the file itself, so each is bounds
+// checked before use - a truncated or corrupt file returns an error rather than panicking.
+func parseMO(data []byte) (header string, entries []gettextEntry, err error) {
+	if len(data) < 28 {
+		return "", nil, fmt.Errorf("lang: mo file too short")
+	}
+
+	bo := binary.ByteOrder(binary.LittleEndian)
+	if binary.LittleEndian.Uint32(data[0:4]) == moMagicBE {
+		bo = binary.BigEndian
+	}
+
+	count := bo.Uint32(data[8:12])
+	origOffset := bo.Uint32(data[12:16])
+	transOffset := bo.Uint32(data[16:20])
+
+	for i := uint32(0); i < count; i++ {
+		orig, err := readMOString(data, bo, origOffset, i)
+		if err != nil {
+			return "", nil, err
+		}
+		trans, err := readMOString(data, bo, transOffset, i)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if orig == "" {
+			header = trans
+			continue
+		}
+
+		entry := gettextEntry{forms: map[int]string{}}
+		entry.id = orig
+		if idx := strings.IndexByte(orig, 0); idx >= 0 {
+			entry.id = orig[:idx]
+			entry.idPlural = orig[idx+1:]
+		}
+
+		for idx, form := range strings.Split(trans, "\x00") {
+			entry.forms[idx] = form
+		}
+		entries = append(entries, entry)
+	}
+	return header, entries, nil
+}
+
+// readMOString reads the i'th string from the length/offset table starting at tableOffset,
+// checking every computed bound against len(data) before any slice expression is evaluated.
+func readMOString(data []byte, bo binary.ByteOrder, tableOffset, i uint32) (string, error) {
+	entryOffset := uint64(tableOffset) + uint64(i)*8
+	if entryOffset+8 > uint64(len(data)) {
+		return "", fmt.Errorf("lang: mo file truncated: string table entry %d out of range", i)
+	}
+
+	strLen := bo.Uint32(data[entryOffset : entryOffset+4])
+	strOff := bo.Uint32(data[entryOffset+4 : entryOffset+8])
+
+	end := uint64(strOff) + uint64(strLen)
+	if end > uint64(len(data)) {
+		return "", fmt.Errorf("lang: mo file truncated: string %d out of range", i)
+	}
+
+	return string(data[strOff:end]), nil
+}
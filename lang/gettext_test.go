@@ -0,0 +1,78 @@
+package lang
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestParsePOPluralForms exercises a Polish-style catalog whose Plural-Forms header selects
+// between three msgstr[] forms (one/few/many), guarding against earlier behaviour that only
+// ever kept the first and last index and silently dropped "few"/"many" for 3+-form languages.
+func TestParsePOPluralForms(t *testing.T) {
+	po := `msgid ""
+msgstr ""
+"Plural-Forms: nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+msgid "apple"
+msgid_plural "apples"
+msgstr[0] "jablko"
+msgstr[1] "jablka"
+msgstr[2] "jablek"
+`
+
+	header, entries, err := parsePO([]byte(po))
+	if err != nil {
+		t.Fatalf("parsePO returned error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1", len(entries))
+	}
+
+	messages := buildMessages(language.MustParse("pl"), header, entries)
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+
+	msg := messages[0]
+	if msg.One != "jablko" {
+		t.Errorf("One = %q, want %q", msg.One, "jablko")
+	}
+	if msg.Few != "jablka" {
+		t.Errorf("Few = %q, want %q", msg.Few, "jablka")
+	}
+	if msg.Many != "jablek" {
+		t.Errorf("Many = %q, want %q", msg.Many, "jablek")
+	}
+}
+
+// TestParseMOTruncated confirms that corrupt/truncated .mo data returns an error instead of
+// panicking with a slice-bounds-out-of-range.
+func TestParseMOTruncated(t *testing.T) {
+	data := make([]byte, 28)
+	binary.LittleEndian.PutUint32(data[0:4], moMagicLE)
+	binary.LittleEndian.PutUint32(data[8:12], 1)  // count: claims one entry...
+	binary.LittleEndian.PutUint32(data[12:16], 0) // ...but the string tables were never written
+	binary.LittleEndian.PutUint32(data[16:20], 0)
+
+	if _, _, err := parseMO(data); err == nil {
+		t.Fatal("expected an error for truncated mo data, got nil")
+	}
+}
+
+// TestPOMORoundTrip confirms a simple (non-plural) PO entry and its MO-style counterpart produce
+// the same translation.
+func TestPOMORoundTrip(t *testing.T) {
+	po := `msgid "hello"
+msgstr "bonjour"
+`
+	header, entries, err := parsePO([]byte(po))
+	if err != nil {
+		t.Fatalf("parsePO returned error: %v", err)
+	}
+	messages := buildMessages(language.French, header, entries)
+	if len(messages) != 1 || messages[0].Other != "bonjour" {
+		t.Fatalf("got %+v, want a single message with Other=%q", messages, "bonjour")
+	}
+}
@@ -0,0 +1,531 @@
+package lang
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// MessageFormat selects the placeholder syntax used to render a translated message.
+//
+// Since 2.6
+type MessageFormat int
+
+const (
+	// FormatGo renders placeholders using Go's text/template syntax, for example "{{.Name}}".
+	// This is the default and matches the behaviour of earlier Fyne releases.
+	//
+	// Since 2.6
+	FormatGo MessageFormat = iota
+
+	// FormatICU renders placeholders using ICU MessageFormat syntax, for example
+	// "{name}" or "{count, plural, one {# item} other {# items}}". This gives translators
+	// access to CLDR plural, select and number/date formatting rules.
+	//
+	// Since 2.6
+	FormatICU
+)
+
+var (
+	icuMu         sync.RWMutex
+	defaultFormat = FormatGo
+
+	// icuMessageIDs is keyed by bundle so that a Localizer's own namespaced messages, see
+	// lang/localizer.go, don't share ICU-format flags with the package-wide bundle or with any
+	// other Localizer's bundle.
+	icuMessageIDs = map[*i18n.Bundle]map[string]bool{}
+)
+
+// SetMessageFormat changes the placeholder syntax used for every message that does not
+// explicitly declare its own format. A translation file can still opt a single message into
+// ICU MessageFormat by giving it a "format" field of "icu", regardless of this setting.
+//
+// Since 2.6
+func SetMessageFormat(f MessageFormat) {
+	icuMu.Lock()
+	defer icuMu.Unlock()
+
+	defaultFormat = f
+}
+
+func useICUFormat(bundle *i18n.Bundle, key string) bool {
+	icuMu.RLock()
+	defer icuMu.RUnlock()
+
+	return defaultFormat == FormatICU || icuMessageIDs[bundle][key]
+}
+
+// detectICUFormat wraps an UnmarshalFunc so that any message carrying a "format": "icu" field
+// is remembered against bundle, letting Localize and friends (or a Localizer's own lookup)
+// choose the ICU renderer for that message ID.
+func detectICUFormat(bundle *i18n.Bundle, fn func([]byte, any) error) func([]byte, any) error {
+	return func(data []byte, v any) error {
+		if err := fn(data, v); err != nil {
+			return err
+		}
+
+		// go-i18n always invokes an UnmarshalFunc as fn(buf, &raw) with raw declared as a plain
+		// interface{}, so v arrives as *interface{}, not *map[string]any.
+		ptr, ok := v.(*any)
+		if !ok || ptr == nil {
+			return nil
+		}
+		raw, ok := (*ptr).(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		icuMu.Lock()
+		defer icuMu.Unlock()
+		for id, entry := range raw {
+			fields, ok := entry.(map[string]any)
+			if !ok {
+				continue
+			}
+			if format, _ := fields["format"].(string); strings.EqualFold(format, "icu") {
+				if icuMessageIDs[bundle] == nil {
+					icuMessageIDs[bundle] = map[string]bool{}
+				}
+				icuMessageIDs[bundle][id] = true
+			}
+		}
+		return nil
+	}
+}
+
+// renderICUMessage parses msg as an ICU MessageFormat string and renders it for tag, resolving
+// placeholders against scopes in order - the first scope containing a matching name wins.
+// If msg cannot be parsed it is returned unmodified and the template engine fallback applies.
+func renderICUMessage(tag language.Tag, msg string, scopes ...any) string {
+	nodes, err := parseICU(msg)
+	if err != nil {
+		fyne.LogError("Failed to parse ICU message", err)
+		return msg
+	}
+
+	out := &strings.Builder{}
+	writeICUNodes(out, tag, nodes, scopes)
+	return out.String()
+}
+
+type icuNode any
+
+type icuText string
+
+type icuArg struct{ name string }
+
+type icuNumberArg struct {
+	name, style string
+}
+
+type icuDateArg struct {
+	name, style string
+}
+
+type icuPluralArg struct {
+	name    string
+	offset  int
+	ordinal bool // true for "selectordinal", which matches plural.Ordinal rather than plural.Cardinal
+	forms   map[string][]icuNode
+}
+
+type icuSelectArg struct {
+	name  string
+	forms map[string][]icuNode
+}
+
+// parseICU parses an ICU MessageFormat string into a sequence of literal text and argument nodes.
+func parseICU(s string) ([]icuNode, error) {
+	var nodes []icuNode
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, icuText(text.String()))
+			text.Reset()
+		}
+	}
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '\'':
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				text.WriteRune('\'')
+				i++
+				continue
+			}
+			j := i + 1
+			for j < len(runes) && runes[j] != '\'' {
+				text.WriteRune(runes[j])
+				j++
+			}
+			i = j
+		case '{':
+			end, err := matchingBrace(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			node, err := parseICUArgument(string(runes[i+1 : end]))
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+			i = end
+		default:
+			text.WriteRune(r)
+		}
+	}
+	flush()
+	return nodes, nil
+}
+
+// matchingBrace returns the index of the '}' that closes the '{' found at open.
+func matchingBrace(runes []rune, open int) (int, error) {
+	depth := 1
+	j := open + 1
+	for j < len(runes) && depth > 0 {
+		switch runes[j] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		if depth > 0 {
+			j++
+		}
+	}
+	if depth != 0 {
+		return 0, fmt.Errorf("lang: unbalanced '{' in ICU message %q", string(runes))
+	}
+	return j, nil
+}
+
+func parseICUArgument(inner string) (icuNode, error) {
+	parts := splitTopLevel(inner, 3)
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	name := parts[0]
+	if len(parts) == 1 {
+		return icuArg{name: name}, nil
+	}
+
+	style := ""
+	if len(parts) > 2 {
+		style = parts[2]
+	}
+
+	switch parts[1] {
+	case "plural":
+		return parseICUPlural(name, style, false)
+	case "selectordinal":
+		return parseICUPlural(name, style, true)
+	case "select":
+		return parseICUSelect(name, style)
+	case "number":
+		return icuNumberArg{name: name, style: style}, nil
+	case "date", "time":
+		return icuDateArg{name: name, style: style}, nil
+	default:
+		return icuArg{name: name}, nil
+	}
+}
+
+// splitTopLevel splits s on commas that are not nested inside a '{...}' pair, stopping once
+// limit parts have been produced (the remainder is kept intact as the final part).
+func splitTopLevel(s string, limit int) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range s {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 && len(parts) < limit-1 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func parseICUPlural(name, rest string, ordinal bool) (icuNode, error) {
+	rest = strings.TrimSpace(rest)
+	offset := 0
+	if strings.HasPrefix(rest, "offset:") {
+		rest = strings.TrimSpace(strings.TrimPrefix(rest, "offset:"))
+		end := strings.IndexAny(rest, " \t")
+		if end == -1 {
+			return nil, fmt.Errorf("lang: malformed plural offset in %q", rest)
+		}
+		n, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return nil, err
+		}
+		offset = n
+		rest = strings.TrimSpace(rest[end:])
+	}
+
+	forms, err := parseICUForms(rest)
+	if err != nil {
+		return nil, err
+	}
+	return icuPluralArg{name: name, offset: offset, ordinal: ordinal, forms: forms}, nil
+}
+
+func parseICUSelect(name, rest string) (icuNode, error) {
+	forms, err := parseICUForms(rest)
+	if err != nil {
+		return nil, err
+	}
+	return icuSelectArg{name: name, forms: forms}, nil
+}
+
+// parseICUForms parses a sequence of "keyword {message}" pairs, as used by plural and select.
+func parseICUForms(rest string) (map[string][]icuNode, error) {
+	forms := make(map[string][]icuNode)
+	runes := []rune(rest)
+
+	i := 0
+	for i < len(runes) {
+		for i < len(runes) && (runes[i] == ' ' || runes[i] == '\t' || runes[i] == '\n') {
+			i++
+		}
+		if i >= len(runes) {
+			break
+		}
+
+		start := i
+		for i < len(runes) && runes[i] != '{' && runes[i] != ' ' {
+			i++
+		}
+		keyword := string(runes[start:i])
+		for i < len(runes) && runes[i] != '{' {
+			i++
+		}
+		if i >= len(runes) {
+			return nil, fmt.Errorf("lang: expected '{' after %q in ICU message", keyword)
+		}
+
+		end, err := matchingBrace(runes, i)
+		if err != nil {
+			return nil, err
+		}
+		nodes, err := parseICU(string(runes[i+1 : end]))
+		if err != nil {
+			return nil, err
+		}
+		forms[keyword] = nodes
+		i = end + 1
+	}
+	return forms, nil
+}
+
+func writeICUNodes(out *strings.Builder, tag language.Tag, nodes []icuNode, scopes []any) {
+	for _, n := range nodes {
+		writeICUNode(out, tag, n, scopes)
+	}
+}
+
+func writeICUNode(out *strings.Builder, tag language.Tag, n icuNode, scopes []any) {
+	switch v := n.(type) {
+	case icuText:
+		out.WriteString(string(v))
+	case icuArg:
+		out.WriteString(fmt.Sprint(lookupICUValue(scopes, v.name)))
+	case icuNumberArg:
+		out.WriteString(formatICUNumber(tag, lookupICUValue(scopes, v.name), v.style))
+	case icuDateArg:
+		out.WriteString(formatICUDate(lookupICUValue(scopes, v.name), v.style))
+	case icuPluralArg:
+		writeICUPlural(out, tag, v, scopes)
+	case icuSelectArg:
+		writeICUSelect(out, tag, v, scopes)
+	}
+}
+
+// pluralFormKeyword maps a CLDR plural.Form, which is a plain byte with no String method, to
+// the lowercase keyword used by ICU plural/selectordinal forms ("zero", "one", "other", ...).
+func pluralFormKeyword(f plural.Form) string {
+	switch f {
+	case plural.Zero:
+		return "zero"
+	case plural.One:
+		return "one"
+	case plural.Two:
+		return "two"
+	case plural.Few:
+		return "few"
+	case plural.Many:
+		return "many"
+	default:
+		return "other"
+	}
+}
+
+func writeICUPlural(out *strings.Builder, tag language.Tag, p icuPluralArg, scopes []any) {
+	n, _ := toInt(lookupICUValue(scopes, p.name))
+	adjusted := n - p.offset
+
+	nodes, found := p.forms[fmt.Sprintf("=%d", n)]
+	if !found {
+		rules := plural.Cardinal
+		if p.ordinal {
+			rules = plural.Ordinal
+		}
+		form := rules.MatchPlural(tag, adjusted, 0, 0, 0, 0)
+		if nodes, found = p.forms[pluralFormKeyword(form)]; !found {
+			nodes = p.forms["other"]
+		}
+	}
+
+	for _, node := range nodes {
+		if text, ok := node.(icuText); ok && strings.Contains(string(text), "#") {
+			out.WriteString(strings.ReplaceAll(string(text), "#", strconv.Itoa(adjusted)))
+			continue
+		}
+		writeICUNode(out, tag, node, scopes)
+	}
+}
+
+func writeICUSelect(out *strings.Builder, tag language.Tag, s icuSelectArg, scopes []any) {
+	key := fmt.Sprint(lookupICUValue(scopes, s.name))
+	nodes, found := s.forms[key]
+	if !found {
+		nodes = s.forms["other"]
+	}
+	writeICUNodes(out, tag, nodes, scopes)
+}
+
+// lookupICUValue resolves name against scopes in order, returning "" if no scope defines it.
+func lookupICUValue(scopes []any, name string) any {
+	for _, data := range scopes {
+		if v, ok := lookupICUValueIn(data, name); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+func lookupICUValueIn(data any, name string) (any, bool) {
+	if data == nil {
+		return nil, false
+	}
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		val := v.MapIndex(reflect.ValueOf(name))
+		if !val.IsValid() {
+			return nil, false
+		}
+		return val.Interface(), true
+	case reflect.Struct:
+		if name == "" {
+			return nil, false
+		}
+		field := v.FieldByName(strings.ToUpper(name[:1]) + name[1:])
+		if !field.IsValid() {
+			field = v.FieldByName(name)
+		}
+		if !field.IsValid() {
+			return nil, false
+		}
+		return field.Interface(), true
+	}
+	return nil, false
+}
+
+func toInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	case string:
+		i, err := strconv.Atoi(n)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func formatICUNumber(tag language.Tag, v any, style string) string {
+	f, ok := toFloat(v)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+
+	p := message.NewPrinter(tag)
+	switch strings.TrimSpace(style) {
+	case "percent":
+		return p.Sprintf("%v", number.Percent(f))
+	default:
+		return p.Sprintf("%v", number.Decimal(f))
+	}
+}
+
+var icuDateLayouts = map[string]string{
+	"short":  "2006-01-02",
+	"medium": "Jan 2, 2006",
+	"long":   "January 2, 2006",
+	"full":   "Monday, January 2, 2006",
+}
+
+// formatICUDate formats v, which must be a time.Time, using one of the ICU date styles.
+// This is a best-effort mapping and does not yet draw its layouts from CLDR per locale.
+func formatICUDate(v any, style string) string {
+	t, ok := v.(time.Time)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+
+	layout, ok := icuDateLayouts[strings.TrimSpace(style)]
+	if !ok {
+		layout = icuDateLayouts["short"]
+	}
+	return t.Format(layout)
+}
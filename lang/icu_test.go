@@ -0,0 +1,83 @@
+package lang
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+func TestRenderICUMessagePlural(t *testing.T) {
+	msg := "{count, plural, one {# item} other {# items}}"
+
+	if got := renderICUMessage(language.English, msg, map[string]any{"count": 1}); got != "1 item" {
+		t.Errorf("got %q, want %q", got, "1 item")
+	}
+	if got := renderICUMessage(language.English, msg, map[string]any{"count": 5}); got != "5 items" {
+		t.Errorf("got %q, want %q", got, "5 items")
+	}
+}
+
+func TestRenderICUMessagePluralExplicitMatch(t *testing.T) {
+	msg := "{count, plural, =0 {no items} one {# item} other {# items}}"
+
+	if got := renderICUMessage(language.English, msg, map[string]any{"count": 0}); got != "no items" {
+		t.Errorf("got %q, want %q", got, "no items")
+	}
+}
+
+func TestRenderICUMessageSelect(t *testing.T) {
+	msg := "{gender, select, male {He} female {She} other {They}} replied"
+
+	if got := renderICUMessage(language.English, msg, map[string]any{"gender": "female"}); got != "She replied" {
+		t.Errorf("got %q, want %q", got, "She replied")
+	}
+	if got := renderICUMessage(language.English, msg, map[string]any{"gender": "unknown"}); got != "They replied" {
+		t.Errorf("got %q, want %q", got, "They replied")
+	}
+}
+
+// TestAddTranslationsFSICUFormatOptIn round-trips a message carrying a "format": "icu" field
+// through AddTranslationsFS and go-i18n's Localize, guarding against detectICUFormat failing to
+// recognise the *interface{} shape go-i18n actually hands unmarshal funcs.
+func TestAddTranslationsFSICUFormatOptIn(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fr.json": {Data: []byte(`{"icu-greeting": {"other": "{name} dit bonjour", "format": "icu"}}`)},
+	}
+	if err := AddTranslationsFS(fsys, "."); err != nil {
+		t.Fatalf("AddTranslationsFS returned error: %v", err)
+	}
+
+	if !useICUFormat(bundle, "icu-greeting") {
+		t.Fatalf("expected %q to be detected as ICU-formatted", "icu-greeting")
+	}
+
+	loc := i18n.NewLocalizer(bundle, "fr")
+	ret, err := loc.Localize(&i18n.LocalizeConfig{DefaultMessage: &i18n.Message{ID: "icu-greeting"}})
+	if err != nil {
+		t.Fatalf("Localize returned error: %v", err)
+	}
+	if got := renderICUMessage(language.French, ret, map[string]any{"name": "Ada"}); got != "Ada dit bonjour" {
+		t.Errorf("got %q, want %q", got, "Ada dit bonjour")
+	}
+}
+
+func TestRenderICUMessageSelectOrdinal(t *testing.T) {
+	msg := "{rank, selectordinal, one {#st} two {#nd} few {#rd} other {#th}}"
+
+	cases := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 21: "21st"}
+	for rank, want := range cases {
+		if got := renderICUMessage(language.English, msg, map[string]any{"rank": rank}); got != want {
+			t.Errorf("rank %d: got %q, want %q", rank, got, want)
+		}
+	}
+}
+
+func TestPluralFormKeyword(t *testing.T) {
+	if pluralFormKeyword(plural.Other) != "other" {
+		t.Errorf("expected default plural category to map to %q", "other")
+	}
+}
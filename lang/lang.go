@@ -6,13 +6,19 @@ package lang
 import (
 	"embed"
 	"encoding/json"
+	"io/fs"
 	"log"
+	"os"
+	"path"
 	"strings"
 	"text/template"
 
 	"fyne.io/fyne/v2"
+	"github.com/BurntSushi/toml"
 	"github.com/fyne-io/go-locale"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"gopkg.in/ini.v1"
+	"gopkg.in/yaml.v3"
 
 	"golang.org/x/text/language"
 )
@@ -36,6 +42,9 @@ var (
 	//go:embed translations
 	translations embed.FS
 	translated   []language.Tag
+	currentTag   language.Tag
+
+	unmarshalFormats = map[string]bool{}
 )
 
 // Localize asks the translation engine to translate a string, this behaves like the gettext "_" function.
@@ -55,7 +64,8 @@ func LocalizeKey(key, fallback string, data ...any) string {
 		d0 = data[0]
 	}
 
-	ret, err := localizer.Localize(&i18n.LocalizeConfig{
+	active, tag := activeLocalizer()
+	ret, err := active.Localize(&i18n.LocalizeConfig{
 		DefaultMessage: &i18n.Message{
 			ID:    key,
 			Other: fallback,
@@ -67,6 +77,9 @@ func LocalizeKey(key, fallback string, data ...any) string {
 		fyne.LogError("Translation failure", err)
 		return fallbackWithData(key, fallback, d0)
 	}
+	if useICUFormat(bundle, key) {
+		return renderICUMessage(tag, ret, d0)
+	}
 	return ret
 }
 
@@ -80,7 +93,8 @@ func LocalizePlural(in string, count int, data ...any) string {
 		d0 = data[0]
 	}
 
-	ret, err := localizer.Localize(&i18n.LocalizeConfig{
+	active, tag := activeLocalizer()
+	ret, err := active.Localize(&i18n.LocalizeConfig{
 		DefaultMessage: &i18n.Message{
 			ID:    in,
 			Other: in,
@@ -93,6 +107,9 @@ func LocalizePlural(in string, count int, data ...any) string {
 		fyne.LogError("Translation failure", err)
 		return fallbackWithData(in, in, d0)
 	}
+	if useICUFormat(bundle, in) {
+		return renderICUMessage(tag, ret, d0, map[string]any{"count": count})
+	}
 	return ret
 }
 
@@ -109,14 +126,106 @@ func AddTranslationsForLocale(data []byte, l fyne.Locale) error {
 	return addLanguage(data, l.String()+".json")
 }
 
+// AddTranslationsFS loads every translation file found in dir, a directory of the given filesystem.
+// The language each file relates to is inferred from its name, for example "fr.yaml" or
+// "zh-Hans.toml", and the format is chosen from the file extension. Files whose extension has no
+// registered unmarshal function, see RegisterUnmarshalFunc, are skipped. JSON, YAML and TOML are
+// supported out of the box.
+//
+// Since 2.6
+func AddTranslationsFS(fsys fs.FS, dir string) error {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if f.IsDir() || !unmarshalFormats[extOf(f.Name())] {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, f.Name()))
+		if err != nil {
+			return err
+		}
+		if err := addLanguage(data, f.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddTranslationsDir loads every translation file found in the directory at path on disk.
+// See AddTranslationsFS for details of supported formats and how the locale is inferred.
+//
+// Since 2.6
+func AddTranslationsDir(path string) error {
+	return AddTranslationsFS(os.DirFS(path), ".")
+}
+
+// RegisterUnmarshalFunc registers a function to unmarshal translation files with the given
+// extension (without the leading dot), allowing AddTranslationsFS and AddTranslationsDir to load
+// additional file formats. JSON, YAML and TOML are registered automatically.
+//
+// Since 2.6
+func RegisterUnmarshalFunc(ext string, fn func([]byte, any) error) {
+	bundle.RegisterUnmarshalFunc(ext, detectICUFormat(bundle, fn))
+	unmarshalFormats[ext] = true
+}
+
 func addLanguage(data []byte, name string) error {
-	_, err := bundle.ParseMessageFileBytes(data, name)
-	return err
+	mf, err := bundle.ParseMessageFileBytes(data, name)
+	if err != nil {
+		return err
+	}
+	rememberTranslated(mf.Tag)
+	return nil
+}
+
+func extOf(name string) string {
+	i := strings.LastIndex(name, ".")
+	if i == -1 {
+		return ""
+	}
+	return name[i+1:]
+}
+
+func unmarshalINI(data []byte, v any) error {
+	cfg, err := ini.Load(data)
+	if err != nil {
+		return err
+	}
+
+	out := make(map[string]any)
+	for _, section := range cfg.Sections() {
+		for _, key := range section.Keys() {
+			out[key.Name()] = key.Value()
+		}
+	}
+
+	encoded, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, v)
+}
+
+// defaultUnmarshalFuncs lists the formats understood out of the box, for use when seeding the
+// package-wide bundle as well as any bundle owned by a Localizer created with NewLocalizer.
+func defaultUnmarshalFuncs() map[string]func([]byte, any) error {
+	return map[string]func([]byte, any) error{
+		"json": json.Unmarshal,
+		"yaml": yaml.Unmarshal,
+		"toml": toml.Unmarshal,
+		"ini":  unmarshalINI,
+	}
 }
 
 func init() {
 	bundle = i18n.NewBundle(language.English)
-	bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
+	for ext, fn := range defaultUnmarshalFuncs() {
+		RegisterUnmarshalFunc(ext, fn)
+	}
 
 	loadTranslationsFromFS(translations, "translations")
 
@@ -129,6 +238,7 @@ func init() {
 	str := closestSupportedLocale(all).LanguageString()
 	setupLang(str)
 	localizer = i18n.NewLocalizer(bundle, str)
+	currentTag = language.Make(str)
 }
 
 func fallbackWithData(key, fallback string, data any) string {
@@ -161,12 +271,25 @@ func loadTranslationsFromFS(fs embed.FS, dir string) {
 		if !strings.Contains(f.Name(), "template") {
 			name = f.Name()[5 : len(f.Name())-5]
 		}
-		tag := language.Make(name)
-		translated = append(translated, tag)
+		rememberTranslated(language.Make(name))
+	}
+}
+
+// rememberTranslated records tag as an available language, if it is not already known.
+func rememberTranslated(tag language.Tag) {
+	langMu.Lock()
+	defer langMu.Unlock()
+
+	for _, t := range translated {
+		if t == tag {
+			return
+		}
 	}
+	translated = append(translated, tag)
 }
 
 // A utility for setting up languages - available to unit tests for overriding system
 func setupLang(lang string) {
 	localizer = i18n.NewLocalizer(bundle, lang)
+	currentTag = language.Make(lang)
 }
\ No newline at end of file
@@ -0,0 +1,101 @@
+package lang
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// TestAddTranslationsFSFormats loads one translation file per built-in format (json, yaml, toml,
+// ini) from an fs.FS and confirms each unmarshals into a usable message.
+func TestAddTranslationsFSFormats(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fr.json": {Data: []byte(`{"json-hello": {"other": "json translation"}}`)},
+		"fr.yaml": {Data: []byte("yaml-hello:\n  other: yaml translation\n")},
+		"fr.toml": {Data: []byte("[toml-hello]\nother = \"toml translation\"\n")},
+		"fr.ini":  {Data: []byte("ini-hello = ini translation\n")},
+	}
+
+	if err := AddTranslationsFS(fsys, "."); err != nil {
+		t.Fatalf("AddTranslationsFS returned error: %v", err)
+	}
+
+	loc := i18n.NewLocalizer(bundle, "fr")
+	for id, want := range map[string]string{
+		"json-hello": "json translation",
+		"yaml-hello": "yaml translation",
+		"toml-hello": "toml translation",
+		"ini-hello":  "ini translation",
+	} {
+		got, err := loc.Localize(&i18n.LocalizeConfig{DefaultMessage: &i18n.Message{ID: id}})
+		if err != nil {
+			t.Errorf("Localize(%q) returned error: %v", id, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("Localize(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+// TestAddTranslationsDir mirrors TestAddTranslationsFSFormats but loads from an OS directory,
+// exercising the os.DirFS wiring in AddTranslationsDir.
+func TestAddTranslationsDir(t *testing.T) {
+	dir := t.TempDir()
+	fixture := []byte(`{"dir-hello": {"other": "dir translation"}}`)
+	if err := os.WriteFile(filepath.Join(dir, "de.json"), fixture, 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	if err := AddTranslationsDir(dir); err != nil {
+		t.Fatalf("AddTranslationsDir returned error: %v", err)
+	}
+
+	loc := i18n.NewLocalizer(bundle, "de")
+	got, err := loc.Localize(&i18n.LocalizeConfig{DefaultMessage: &i18n.Message{ID: "dir-hello"}})
+	if err != nil {
+		t.Fatalf("Localize returned error: %v", err)
+	}
+	if got != "dir translation" {
+		t.Errorf("got %q, want %q", got, "dir translation")
+	}
+}
+
+// TestRegisterUnmarshalFuncCustomFormat confirms an application-registered format participates
+// in AddTranslationsFS alongside the built-in ones.
+func TestRegisterUnmarshalFuncCustomFormat(t *testing.T) {
+	RegisterUnmarshalFunc("custom", json.Unmarshal)
+
+	fsys := fstest.MapFS{
+		"es.custom": {Data: []byte(`{"custom-hello": {"other": "custom translation"}}`)},
+	}
+	if err := AddTranslationsFS(fsys, "."); err != nil {
+		t.Fatalf("AddTranslationsFS returned error: %v", err)
+	}
+
+	loc := i18n.NewLocalizer(bundle, "es")
+	got, err := loc.Localize(&i18n.LocalizeConfig{DefaultMessage: &i18n.Message{ID: "custom-hello"}})
+	if err != nil {
+		t.Fatalf("Localize returned error: %v", err)
+	}
+	if got != "custom translation" {
+		t.Errorf("got %q, want %q", got, "custom translation")
+	}
+}
+
+// TestUnmarshalINI confirms unmarshalINI flattens an ini file's keys into the map[string]any
+// shape go-i18n's UnmarshalFunc contract expects.
+func TestUnmarshalINI(t *testing.T) {
+	var out map[string]any
+	data := []byte("greeting = hello\nfarewell = bye\n")
+	if err := unmarshalINI(data, &out); err != nil {
+		t.Fatalf("unmarshalINI returned error: %v", err)
+	}
+	if out["greeting"] != "hello" || out["farewell"] != "bye" {
+		t.Errorf("got %+v, want greeting=hello farewell=bye", out)
+	}
+}
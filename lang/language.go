@@ -0,0 +1,104 @@
+package lang
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	langMu    sync.RWMutex
+	listeners []func(language.Tag)
+)
+
+// SetLanguage switches the language used to resolve translations at runtime and notifies any
+// listener registered with AddChangeListener, allowing widgets to refresh translated strings
+// without restarting the app. It returns an error if tag is not one of AvailableLanguages.
+//
+// Since 2.6
+func SetLanguage(tag language.Tag) error {
+	found := false
+	for _, t := range AvailableLanguages() {
+		if t == tag {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("lang: no translations loaded for language %q", tag)
+	}
+
+	publishLanguage(tag, tag.String())
+	return nil
+}
+
+// publishLanguage rebuilds the active localizer under langMu and notifies change listeners
+// with tag. It is the common write path for SetLanguage and SetPreferredLanguages - localeTags
+// is the (possibly multi-entry) fallback chain passed to i18n.NewLocalizer.
+func publishLanguage(tag language.Tag, localeTags ...string) {
+	langMu.Lock()
+	localizer = i18n.NewLocalizer(bundle, localeTags...)
+	currentTag = tag
+	fns := append([]func(language.Tag){}, listeners...)
+	langMu.Unlock()
+
+	for _, fn := range fns {
+		if fn != nil {
+			fn(tag)
+		}
+	}
+}
+
+// activeLocalizer returns the localizer and language currently in effect, under a read lock, so
+// that Localize, LocalizeKey and LocalizePlural never race with SetLanguage or
+// SetPreferredLanguages rebuilding them.
+func activeLocalizer() (*i18n.Localizer, language.Tag) {
+	langMu.RLock()
+	defer langMu.RUnlock()
+
+	return localizer, currentTag
+}
+
+// CurrentLanguage returns the language that is currently used to resolve translations.
+//
+// Since 2.6
+func CurrentLanguage() language.Tag {
+	langMu.RLock()
+	defer langMu.RUnlock()
+
+	return currentTag
+}
+
+// AvailableLanguages returns every language for which translations have been loaded, whether
+// bundled with the app or added via AddTranslations and its variants.
+//
+// Since 2.6
+func AvailableLanguages() []language.Tag {
+	langMu.RLock()
+	defer langMu.RUnlock()
+
+	return append([]language.Tag{}, translated...)
+}
+
+// AddChangeListener registers fn to be called, with the newly active language, whenever
+// SetLanguage succeeds. The returned cancel function removes the listener.
+//
+// Since 2.6
+func AddChangeListener(fn func(language.Tag)) (cancel func()) {
+	langMu.Lock()
+	defer langMu.Unlock()
+
+	listeners = append(listeners, fn)
+	id := len(listeners) - 1
+
+	return func() {
+		langMu.Lock()
+		defer langMu.Unlock()
+		if id < len(listeners) {
+			listeners[id] = nil
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package lang
+
+import (
+	"sync"
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestConcurrentSetLanguageAndLocalize exercises SetLanguage racing with Localize, guarding
+// against the active localizer/currentTag being read without langMu held.
+func TestConcurrentSetLanguageAndLocalize(t *testing.T) {
+	rememberTranslated(language.English)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_ = SetLanguage(language.English)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			Localize("hello")
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,179 @@
+package lang
+
+import (
+	"io/fs"
+	"path"
+
+	"fyne.io/fyne/v2"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+
+	"golang.org/x/text/language"
+)
+
+// Localizer translates messages under its own namespace, falling back to the plain message key
+// and then to a fallback Localizer (or the package-wide Localize family) when no namespaced
+// translation exists. It lets plugins, dialog libraries and embedded sub-apps ship their own
+// message catalog without their IDs clashing with the host app's.
+//
+// Since 2.6
+type Localizer struct {
+	namespace string
+	bundle    *i18n.Bundle
+	fallback  *Localizer
+	tag       language.Tag // zero value tracks the package-wide CurrentLanguage
+}
+
+// Option configures a Localizer created with NewLocalizer.
+//
+// Since 2.6
+type Option func(*Localizer)
+
+// WithFallbackLocalizer sets the Localizer consulted when a message is found under neither
+// "namespace.key" nor the plain "key". Without this option, a Localizer falls back to the
+// package-wide Localize family of functions.
+//
+// Since 2.6
+func WithFallbackLocalizer(fallback *Localizer) Option {
+	return func(l *Localizer) { l.fallback = fallback }
+}
+
+// WithTranslations loads every translation file found at the root of fsys into the Localizer's
+// own message catalog. See AddTranslationsFS for supported formats and how the locale of each
+// file is inferred from its name.
+//
+// Since 2.6
+func WithTranslations(fsys fs.FS) Option {
+	return func(l *Localizer) {
+		if err := loadTranslationsInto(l.bundle, fsys, "."); err != nil {
+			fyne.LogError("Failed to load localizer translations", err)
+		}
+	}
+}
+
+// WithLanguage pins the Localizer to a specific language instead of tracking the package-wide
+// CurrentLanguage.
+//
+// Since 2.6
+func WithLanguage(tag language.Tag) Option {
+	return func(l *Localizer) { l.tag = tag }
+}
+
+// NewLocalizer creates a Localizer that resolves messages under the given namespace.
+//
+// Since 2.6
+func NewLocalizer(namespace string, opts ...Option) *Localizer {
+	l := &Localizer{
+		namespace: namespace,
+		bundle:    i18n.NewBundle(language.English),
+	}
+	for ext, fn := range defaultUnmarshalFuncs() {
+		l.bundle.RegisterUnmarshalFunc(ext, detectICUFormat(l.bundle, fn))
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Localize asks the Localizer to translate a string. See the package-level Localize for details
+// on templating.
+func (l *Localizer) Localize(in string, data ...any) string {
+	return l.LocalizeKey(in, in, data...)
+}
+
+// LocalizeKey asks the Localizer for the translation with the specific ID, trying
+// "namespace.key" before the plain "key". If neither is found, the fallback Localizer (or the
+// package-wide LocalizeKey) is consulted.
+func (l *Localizer) LocalizeKey(key, fallback string, data ...any) string {
+	var d0 any
+	if len(data) > 0 {
+		d0 = data[0]
+	}
+
+	for _, id := range l.candidateIDs(key) {
+		if ret, ok := l.lookup(id, 0, false, d0); ok {
+			return ret
+		}
+	}
+	if l.fallback != nil {
+		return l.fallback.LocalizeKey(key, fallback, data...)
+	}
+	return LocalizeKey(key, fallback, data...)
+}
+
+// LocalizePlural asks the Localizer to translate a string from one of a number of plural forms,
+// trying "namespace.key" before the plain "key", the same way LocalizeKey does.
+func (l *Localizer) LocalizePlural(in string, count int, data ...any) string {
+	var d0 any
+	if len(data) > 0 {
+		d0 = data[0]
+	}
+
+	for _, id := range l.candidateIDs(in) {
+		if ret, ok := l.lookup(id, count, true, d0); ok {
+			return ret
+		}
+	}
+	if l.fallback != nil {
+		return l.fallback.LocalizePlural(in, count, data...)
+	}
+	return LocalizePlural(in, count, data...)
+}
+
+func (l *Localizer) candidateIDs(key string) []string {
+	return []string{l.namespace + "." + key, key}
+}
+
+// lookup tries to resolve id against the Localizer's own bundle, returning ok false if it holds
+// no translation for id in the effective language - letting the caller try the next candidate.
+func (l *Localizer) lookup(id string, count int, plural bool, data any) (string, bool) {
+	cfg := &i18n.LocalizeConfig{
+		DefaultMessage: &i18n.Message{ID: id, Other: ""},
+		TemplateData:   data,
+	}
+	if plural {
+		cfg.PluralCount = count
+	}
+
+	tag := l.effectiveTag()
+	ret, err := i18n.NewLocalizer(l.bundle, tag.String()).Localize(cfg)
+	if err != nil || ret == "" {
+		return "", false
+	}
+	if useICUFormat(l.bundle, id) {
+		return renderICUMessage(tag, ret, data), true
+	}
+	return ret, true
+}
+
+func (l *Localizer) effectiveTag() language.Tag {
+	if l.tag == (language.Tag{}) {
+		return CurrentLanguage()
+	}
+	return l.tag
+}
+
+// loadTranslationsInto loads every supported translation file found in dir, a directory of
+// fsys, into bundle. It mirrors AddTranslationsFS but targets an arbitrary bundle rather than
+// the package-wide one.
+func loadTranslationsInto(bundle *i18n.Bundle, fsys fs.FS, dir string) error {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if f.IsDir() || !unmarshalFormats[extOf(f.Name())] {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, f.Name()))
+		if err != nil {
+			return err
+		}
+		if _, err := bundle.ParseMessageFileBytes(data, f.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package lang
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/text/language"
+)
+
+// TestLocalizerNamespaceFallbackChain exercises the full candidate chain: a Localizer first
+// tries "namespace.key", then the plain "key" in its own bundle, then its WithFallbackLocalizer,
+// and finally the package-wide Localize family.
+func TestLocalizerNamespaceFallbackChain(t *testing.T) {
+	ownFS := fstest.MapFS{
+		"en.json": {Data: []byte(`{
+			"widget.greeting": {"other": "namespaced hello"},
+			"plain":           {"other": "plain from own bundle"}
+		}`)},
+	}
+	fallbackFS := fstest.MapFS{
+		"en.json": {Data: []byte(`{"shared": {"other": "shared from fallback"}}`)},
+	}
+	fallback := NewLocalizer("fallback", WithTranslations(fallbackFS), WithLanguage(language.English))
+	l := NewLocalizer("widget", WithFallbackLocalizer(fallback), WithTranslations(ownFS), WithLanguage(language.English))
+
+	if got := l.LocalizeKey("greeting", "greeting"); got != "namespaced hello" {
+		t.Errorf("LocalizeKey(%q) = %q, want %q", "greeting", got, "namespaced hello")
+	}
+	if got := l.LocalizeKey("plain", "plain"); got != "plain from own bundle" {
+		t.Errorf("LocalizeKey(%q) = %q, want %q", "plain", got, "plain from own bundle")
+	}
+	if got := l.LocalizeKey("shared", "shared"); got != "shared from fallback" {
+		t.Errorf("LocalizeKey(%q) = %q, want %q", "shared", got, "shared from fallback")
+	}
+	if got := l.LocalizeKey("missing", "fallback text"); got != "fallback text" {
+		t.Errorf("LocalizeKey(%q) = %q, want %q", "missing", got, "fallback text")
+	}
+}
+
+// TestLocalizerWithLanguagePinsTag confirms a Localizer created with WithLanguage resolves
+// messages for that tag regardless of the package-wide CurrentLanguage.
+func TestLocalizerWithLanguagePinsTag(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fr.json": {Data: []byte(`{"greeting": {"other": "bonjour"}}`)},
+	}
+	l := NewLocalizer("pinned", WithTranslations(fsys), WithLanguage(language.French))
+
+	if got := l.LocalizeKey("greeting", "greeting"); got != "bonjour" {
+		t.Errorf("LocalizeKey(%q) = %q, want %q", "greeting", got, "bonjour")
+	}
+}
+
+// TestLocalizerLocalizePlural confirms LocalizePlural resolves the plural form matching count
+// from the Localizer's own namespaced bundle.
+func TestLocalizerLocalizePlural(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": {Data: []byte(`{"cart.items": {"one": "one item", "other": "many items"}}`)},
+	}
+	l := NewLocalizer("cart", WithTranslations(fsys), WithLanguage(language.English))
+
+	if got := l.LocalizePlural("items", 1); got != "one item" {
+		t.Errorf("LocalizePlural(count=1) = %q, want %q", got, "one item")
+	}
+	if got := l.LocalizePlural("items", 5); got != "many items" {
+		t.Errorf("LocalizePlural(count=5) = %q, want %q", got, "many items")
+	}
+}
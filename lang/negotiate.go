@@ -0,0 +1,61 @@
+package lang
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"golang.org/x/text/language"
+)
+
+// MatchLanguages parses preferred, a BCP-47 language preference list with optional ";q=" weights
+// such as an HTTP Accept-Language header (e.g. "en-US,en;q=0.9,fr;q=0.8"), and matches each entry
+// against AvailableLanguages in preference order. The result is a de-duplicated fallback chain
+// suitable for SetPreferredLanguages, or for feeding directly into a go-i18n Localizer.
+//
+// Since 2.6
+func MatchLanguages(preferred []string) []language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(strings.Join(preferred, ","))
+	if err != nil {
+		fyne.LogError("Failed to parse preferred languages", err)
+		return nil
+	}
+
+	avail := AvailableLanguages()
+	if len(avail) == 0 {
+		return nil
+	}
+	matcher := language.NewMatcher(avail)
+
+	var chain []language.Tag
+	seen := map[language.Tag]bool{}
+	for _, t := range tags {
+		_, idx, _ := matcher.Match(t)
+		matched := avail[idx]
+		if !seen[matched] {
+			seen[matched] = true
+			chain = append(chain, matched)
+		}
+	}
+	return chain
+}
+
+// SetPreferredLanguages sets the active language to the best match for preferred, a BCP-47
+// preference list (see MatchLanguages), and builds a fallback chain from the remaining matches
+// so that a message missing from the top choice falls through to the next-best language before
+// reaching the English default. Registered change listeners are notified with the top choice.
+//
+// Since 2.6
+func SetPreferredLanguages(preferred []string) {
+	chain := MatchLanguages(preferred)
+	if len(chain) == 0 {
+		return
+	}
+
+	tags := make([]string, len(chain))
+	for i, t := range chain {
+		tags[i] = t.String()
+	}
+
+	publishLanguage(chain[0], tags...)
+}
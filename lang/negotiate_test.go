@@ -0,0 +1,86 @@
+package lang
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+// TestMatchLanguagesOrdersByQValue confirms MatchLanguages returns the fallback chain ordered by
+// descending "q" weight rather than the order the preferences were listed in.
+func TestMatchLanguagesOrdersByQValue(t *testing.T) {
+	rememberTranslated(language.English)
+	rememberTranslated(language.French)
+	rememberTranslated(language.German)
+
+	got := MatchLanguages([]string{"fr;q=0.5", "de;q=0.9", "en;q=0.1"})
+	want := []language.Tag{language.German, language.French, language.English}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("index %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+// TestMatchLanguagesDedupes confirms two preferred tags that resolve to the same available
+// language (en-US and en-GB both matching English) only appear once in the chain.
+func TestMatchLanguagesDedupes(t *testing.T) {
+	rememberTranslated(language.English)
+	rememberTranslated(language.French)
+
+	got := MatchLanguages([]string{"en-US", "en-GB", "fr"})
+	count := 0
+	for _, tag := range got {
+		if tag == language.English {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("English appeared %d times in %v, want 1", count, got)
+	}
+}
+
+// TestSetPreferredLanguagesUsesTopMatch confirms SetPreferredLanguages activates the best match
+// from the preference list.
+func TestSetPreferredLanguagesUsesTopMatch(t *testing.T) {
+	rememberTranslated(language.English)
+	rememberTranslated(language.French)
+
+	SetPreferredLanguages([]string{"fr", "en"})
+	if CurrentLanguage() != language.French {
+		t.Errorf("CurrentLanguage() = %v, want %v", CurrentLanguage(), language.French)
+	}
+}
+
+// TestMatchLanguagesEmptyAvailableIsNoop confirms MatchLanguages returns nil instead of panicking
+// on an out-of-range avail[idx] when no languages have been registered yet.
+func TestMatchLanguagesEmptyAvailableIsNoop(t *testing.T) {
+	langMu.Lock()
+	saved := translated
+	translated = nil
+	langMu.Unlock()
+	defer func() {
+		langMu.Lock()
+		translated = saved
+		langMu.Unlock()
+	}()
+
+	if got := MatchLanguages([]string{"en"}); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
+
+// TestSetPreferredLanguagesNoMatchIsNoop confirms SetPreferredLanguages leaves the active
+// language untouched when none of the preferences match an available language.
+func TestSetPreferredLanguagesNoMatchIsNoop(t *testing.T) {
+	rememberTranslated(language.English)
+	_ = SetLanguage(language.English)
+
+	SetPreferredLanguages(nil)
+	if CurrentLanguage() != language.English {
+		t.Errorf("CurrentLanguage() = %v, want %v", CurrentLanguage(), language.English)
+	}
+}
@@ -0,0 +1,295 @@
+package lang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pluralExpr evaluates a gettext Plural-Forms expression for a given n, returning the msgstr[]
+// index that expression selects.
+type pluralExpr func(n int) int
+
+// parsePluralForms extracts and compiles the "plural=" expression from a gettext header, such as
+// the entry carried by the msgid "" message. It reports ok=false if header has no Plural-Forms
+// line or the expression cannot be parsed.
+func parsePluralForms(header string) (expr pluralExpr, ok bool) {
+	var exprStr string
+	for _, line := range strings.Split(header, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "Plural-Forms:") {
+			continue
+		}
+		idx := strings.Index(line, "plural=")
+		if idx == -1 {
+			return nil, false
+		}
+		exprStr = line[idx+len("plural="):]
+		if semi := strings.LastIndex(exprStr, ";"); semi != -1 {
+			exprStr = exprStr[:semi]
+		}
+		break
+	}
+	if exprStr == "" {
+		return nil, false
+	}
+
+	p := &pluralParser{tokens: tokenizePlural(exprStr)}
+	node, err := p.parseTernary()
+	if err != nil || p.pos != len(p.tokens) {
+		return nil, false
+	}
+	return func(n int) int { return evalPlural(node, n) }, true
+}
+
+// pluralNode is one of pluralNum, pluralVar, pluralUnary, pluralBinary or pluralTernary.
+type pluralNode any
+
+type pluralNum int
+
+type pluralVar struct{}
+
+type pluralUnary struct {
+	op string
+	x  pluralNode
+}
+
+type pluralBinary struct {
+	op   string
+	x, y pluralNode
+}
+
+type pluralTernary struct {
+	cond, then, els pluralNode
+}
+
+func evalPlural(node pluralNode, n int) int {
+	switch v := node.(type) {
+	case pluralNum:
+		return int(v)
+	case pluralVar:
+		return n
+	case pluralUnary:
+		return boolInt(evalPlural(v.x, n) == 0)
+	case pluralBinary:
+		x, y := evalPlural(v.x, n), evalPlural(v.y, n)
+		switch v.op {
+		case "||":
+			return boolInt(x != 0 || y != 0)
+		case "&&":
+			return boolInt(x != 0 && y != 0)
+		case "==":
+			return boolInt(x == y)
+		case "!=":
+			return boolInt(x != y)
+		case "<":
+			return boolInt(x < y)
+		case ">":
+			return boolInt(x > y)
+		case "<=":
+			return boolInt(x <= y)
+		case ">=":
+			return boolInt(x >= y)
+		case "+":
+			return x + y
+		case "-":
+			return x - y
+		case "*":
+			return x * y
+		case "/":
+			if y == 0 {
+				return 0
+			}
+			return x / y
+		case "%":
+			if y == 0 {
+				return 0
+			}
+			return x % y
+		}
+	case pluralTernary:
+		if evalPlural(v.cond, n) != 0 {
+			return evalPlural(v.then, n)
+		}
+		return evalPlural(v.els, n)
+	}
+	return 0
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// tokenizePlural splits a C-like plural expression ("n%10==1 && n%100!=11 ? 0 : 1") into tokens.
+func tokenizePlural(s string) []string {
+	var tokens []string
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t':
+			i++
+		case r == 'n':
+			tokens = append(tokens, "n")
+			i++
+		case r >= '0' && r <= '9':
+			j := i
+			for j < len(runes) && runes[j] >= '0' && runes[j] <= '9' {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		case r == '=' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, "==")
+			i += 2
+		case r == '!' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, "!=")
+			i += 2
+		case r == '<' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, "<=")
+			i += 2
+		case r == '>' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, ">=")
+			i += 2
+		case r == '&' && i+1 < len(runes) && runes[i+1] == '&':
+			tokens = append(tokens, "&&")
+			i += 2
+		case r == '|' && i+1 < len(runes) && runes[i+1] == '|':
+			tokens = append(tokens, "||")
+			i += 2
+		case strings.ContainsRune("()?:%+-*/<>!", r):
+			tokens = append(tokens, string(r))
+			i++
+		default:
+			i++ // skip anything unrecognised rather than fail the whole parse
+		}
+	}
+	return tokens
+}
+
+type pluralParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *pluralParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *pluralParser) next() string {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *pluralParser) parseTernary() (pluralNode, error) {
+	cond, err := p.parseLevel([]string{"||"}, p.parseLogicalAnd)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != "?" {
+		return cond, nil
+	}
+	p.next()
+	then, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if p.next() != ":" {
+		return nil, fmt.Errorf("lang: expected ':' in plural expression")
+	}
+	els, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	return pluralTernary{cond, then, els}, nil
+}
+
+func (p *pluralParser) parseLogicalAnd() (pluralNode, error) {
+	return p.parseLevel([]string{"&&"}, p.parseEquality)
+}
+
+func (p *pluralParser) parseEquality() (pluralNode, error) {
+	return p.parseLevel([]string{"==", "!="}, p.parseRelational)
+}
+
+func (p *pluralParser) parseRelational() (pluralNode, error) {
+	return p.parseLevel([]string{"<", ">", "<=", ">="}, p.parseAdditive)
+}
+
+func (p *pluralParser) parseAdditive() (pluralNode, error) {
+	return p.parseLevel([]string{"+", "-"}, p.parseMultiplicative)
+}
+
+func (p *pluralParser) parseMultiplicative() (pluralNode, error) {
+	return p.parseLevel([]string{"*", "/", "%"}, p.parseUnary)
+}
+
+func (p *pluralParser) parseLevel(ops []string, next func() (pluralNode, error)) (pluralNode, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for containsToken(ops, p.peek()) {
+		op := p.next()
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		left = pluralBinary{op: op, x: left, y: right}
+	}
+	return left, nil
+}
+
+func (p *pluralParser) parseUnary() (pluralNode, error) {
+	if p.peek() == "!" {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return pluralUnary{op: "!", x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *pluralParser) parsePrimary() (pluralNode, error) {
+	tok := p.next()
+	switch {
+	case tok == "(":
+		node, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, fmt.Errorf("lang: expected ')' in plural expression")
+		}
+		return node, nil
+	case tok == "n":
+		return pluralVar{}, nil
+	case tok != "":
+		n, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, fmt.Errorf("lang: unexpected token %q in plural expression", tok)
+		}
+		return pluralNum(n), nil
+	default:
+		return nil, fmt.Errorf("lang: unexpected end of plural expression")
+	}
+}
+
+func containsToken(ops []string, tok string) bool {
+	for _, op := range ops {
+		if op == tok {
+			return true
+		}
+	}
+	return false
+}